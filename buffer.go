@@ -0,0 +1,107 @@
+package grequests
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// defaultMaxInMemoryBytes is the buffering cap used when RequestOptions.MaxInMemoryBytes is unset.
+const defaultMaxInMemoryBytes = 10 * 1024 * 1024 // 10MB
+
+// internalBuffer holds the bytes read from a Response's body by respBytesBuffer. Bodies that stay
+// within the configured cap are kept entirely in mem; larger bodies are spilled to a temp file so
+// that .String(), .Bytes(), .Json(), and .Xml() can't OOM on huge or unbounded responses.
+type internalBuffer struct {
+	mem  *bytes.Buffer // populated when the body fit within the cap
+	file *os.File      // populated once the body has been spilled to disk
+
+	size int64 // total bytes buffered, in mem or spilled to file
+}
+
+// Reader returns a fresh reader over the buffered content, starting from the beginning.
+func (b *internalBuffer) Reader() io.Reader {
+	if b.file != nil {
+		b.file.Seek(0, io.SeekStart)
+		return b.file
+	}
+
+	return bytes.NewReader(b.mem.Bytes())
+}
+
+// Bytes reads the buffered content in full, from memory or from the spill file.
+func (b *internalBuffer) Bytes() ([]byte, error) {
+	if b.file != nil {
+		if _, err := b.file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return io.ReadAll(b.file)
+	}
+
+	return b.mem.Bytes(), nil
+}
+
+// Close returns the in-memory buffer to the pool and removes the spill file, if one was created.
+func (b *internalBuffer) Close() error {
+	if b.mem != nil {
+		putPooledBuffer(b.mem)
+		b.mem = nil
+	}
+
+	if b.file == nil {
+		return nil
+	}
+
+	name := b.file.Name()
+	b.file.Close()
+
+	return os.Remove(name)
+}
+
+// fillInternalBuffer reads r's body into an internalBuffer, spilling to a temp file once the body
+// grows past maxInMemoryBytes. A maxInMemoryBytes <= 0 falls back to defaultMaxInMemoryBytes.
+func fillInternalBuffer(r io.Reader, contentLength int64, maxInMemoryBytes int64) (*internalBuffer, error) {
+	if maxInMemoryBytes <= 0 {
+		maxInMemoryBytes = defaultMaxInMemoryBytes
+	}
+
+	prefix := getPooledBuffer()
+	if contentLength > 0 && contentLength < maxInMemoryBytes {
+		prefix.Grow(int(contentLength))
+	}
+
+	// Read one byte past the cap so that a body of exactly maxInMemoryBytes still stays in memory.
+	n, err := io.CopyN(prefix, r, maxInMemoryBytes+1)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	if err == io.EOF {
+		// The whole body fit comfortably within the cap.
+		return &internalBuffer{mem: prefix, size: n}, nil
+	}
+
+	// The body is larger than maxInMemoryBytes – spill the buffered prefix plus whatever remains.
+	// The prefix buffer itself is no longer needed once it's on disk, so it goes back to the pool.
+	defer putPooledBuffer(prefix)
+
+	f, err := os.CreateTemp("", "grequests-spill-*")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.Write(prefix.Bytes()); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	rest, err := io.Copy(f, r)
+	if err != nil && err != io.EOF {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+
+	return &internalBuffer{file: f, size: n + rest}, nil
+}
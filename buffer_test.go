@@ -0,0 +1,43 @@
+package grequests
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func newBenchResponse(body []byte) *Response {
+	return &Response{
+		RawResponse: &http.Response{
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+		},
+	}
+}
+
+func BenchmarkResponseBytes(b *testing.B) {
+	payload := bytes.Repeat([]byte("a"), 64*1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		resp := newBenchResponse(payload)
+		resp.Bytes()
+		resp.Release()
+	}
+}
+
+func BenchmarkResponseString(b *testing.B) {
+	payload := bytes.Repeat([]byte("a"), 64*1024)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		resp := newBenchResponse(payload)
+		_ = resp.String()
+		resp.Release()
+	}
+}
@@ -0,0 +1,72 @@
+package grequests
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ChainItem represents a single hop of a redirect chain captured during a round trip – everything
+// from the initial request up to (but not including) the final response that was returned to the
+// caller as Response.
+type ChainItem struct {
+	// URL is the URL that was requested at this hop.
+	URL *url.URL
+
+	// Method is the HTTP method used for this hop.
+	Method string
+
+	// StatusCode is the status code the server returned for this hop.
+	StatusCode int
+
+	// Header holds the response headers for this hop.
+	Header http.Header
+
+	body    []byte
+	bodyErr error
+}
+
+// Body returns the response body captured at this hop. It was read eagerly inside the
+// CheckRedirect hook that produced this ChainItem, since net/http drains and closes the hop's
+// response body itself immediately after CheckRedirect returns.
+func (c *ChainItem) Body() ([]byte, error) {
+	return c.body, c.bodyErr
+}
+
+// Chain returns the redirect history that was followed to produce this Response, ordered from the
+// first request to the hop immediately preceding the final response. It is empty if no redirects
+// were followed.
+func (r *Response) Chain() []*ChainItem {
+	return r.redirectChain
+}
+
+// newChainCheckRedirect returns an http.Client.CheckRedirect hook that appends a ChainItem to r's
+// redirect chain for every hop the client follows before arriving at the final response.
+//
+// http.Client populates the response for a hop on the *next* request it builds for the redirect
+// target, not on the request that was actually sent for that hop – so the response describing
+// via[len(via)-1] is found at req.Response, not via[len(via)-1].Response. The body must be read
+// here too: net/http drains and closes that response's body itself right after CheckRedirect
+// returns, so a reference stashed for later lazy reading would always come back closed.
+func newChainCheckRedirect(r *Response) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > 0 {
+			prev := via[len(via)-1]
+
+			item := &ChainItem{
+				URL:    prev.URL,
+				Method: prev.Method,
+			}
+
+			if req.Response != nil {
+				item.StatusCode = req.Response.StatusCode
+				item.Header = req.Response.Header
+				item.body, item.bodyErr = io.ReadAll(req.Response.Body)
+			}
+
+			r.redirectChain = append(r.redirectChain, item)
+		}
+
+		return nil
+	}
+}
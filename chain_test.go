@@ -0,0 +1,58 @@
+package grequests
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChainCapturesRedirectHops(t *testing.T) {
+	final := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("done"))
+	}))
+	defer final.Close()
+
+	hopBody := bytes.Repeat([]byte("x"), 5000)
+
+	hop := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("X-Hop", "1")
+		w.Header().Set("Location", final.URL)
+		w.WriteHeader(http.StatusFound)
+		w.Write(hopBody)
+	}))
+	defer hop.Close()
+
+	resp := &Response{}
+	client := &http.Client{CheckRedirect: newChainCheckRedirect(resp)}
+
+	httpResp, err := client.Get(hop.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	httpResp.Body.Close()
+
+	chain := resp.Chain()
+	if len(chain) != 1 {
+		t.Fatalf("expected 1 chain hop, got %d", len(chain))
+	}
+
+	hopItem := chain[0]
+	if hopItem.StatusCode != http.StatusFound {
+		t.Errorf("StatusCode = %d, want %d", hopItem.StatusCode, http.StatusFound)
+	}
+	if got := hopItem.Header.Get("X-Hop"); got != "1" {
+		t.Errorf("Header[X-Hop] = %q, want %q", got, "1")
+	}
+	if hopItem.URL.String() != hop.URL {
+		t.Errorf("URL = %q, want %q", hopItem.URL.String(), hop.URL)
+	}
+
+	body, err := hopItem.Body()
+	if err != nil {
+		t.Fatalf("Body: %v", err)
+	}
+	if !bytes.Equal(body, hopBody) {
+		t.Errorf("Body() = %d bytes, want %d bytes", len(body), len(hopBody))
+	}
+}
@@ -0,0 +1,146 @@
+package grequests
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Decoder populates v by decoding from r. Decoders are registered against a Content-Type media
+// type in a DecoderRegistry.
+type Decoder func(r io.Reader, v interface{}) error
+
+// DecoderRegistry maps Content-Type media types (e.g. "application/json") to the Decoder that
+// should populate a caller's struct from the response body. Response.Decode consults a registry –
+// either the one set on RequestOptions.Decoders for the request, or defaultDecoderRegistry.
+type DecoderRegistry struct {
+	mu       sync.RWMutex
+	decoders map[string]Decoder
+}
+
+// NewDecoderRegistry returns a DecoderRegistry pre-populated with JSON and XML decoders.
+func NewDecoderRegistry() *DecoderRegistry {
+	registry := &DecoderRegistry{decoders: map[string]Decoder{}}
+
+	registry.Register("application/json", decodeJSON)
+	registry.Register("text/json", decodeJSON)
+	registry.Register("application/xml", decodeXML)
+	registry.Register("text/xml", decodeXML)
+
+	return registry
+}
+
+// Register associates contentType with dec, overriding any decoder already registered for it.
+func (reg *DecoderRegistry) Register(contentType string, dec Decoder) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.decoders[contentType] = dec
+}
+
+func (reg *DecoderRegistry) lookup(contentType string) (Decoder, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	dec, ok := reg.decoders[contentType]
+
+	return dec, ok
+}
+
+// defaultDecoderRegistry is consulted by Response.Decode when the request didn't set
+// RequestOptions.Decoders.
+var defaultDecoderRegistry = NewDecoderRegistry()
+
+// SetDefaultDecoderRegistry replaces the package-wide default DecoderRegistry.
+func SetDefaultDecoderRegistry(registry *DecoderRegistry) {
+	defaultDecoderRegistry = registry
+}
+
+func decodeJSON(r io.Reader, v interface{}) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+func decodeXML(r io.Reader, v interface{}) error {
+	if err := xml.NewDecoder(r).Decode(v); err != nil && err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// mediaType strips any parameters (e.g. "; charset=utf-8") off a Content-Type header value.
+func mediaType(contentType string) string {
+	parsed, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])
+	}
+	return parsed
+}
+
+// Decode populates v by dispatching on the response's Content-Type to a registered Decoder – JSON
+// and XML out of the box, with YAML, MessagePack, protobuf, form-encoded, or anything else a
+// caller registers via RequestOptions.Decoders or SetDefaultDecoderRegistry.
+func (r *Response) Decode(v interface{}) error {
+	registry := r.decoders
+	if registry == nil {
+		registry = defaultDecoderRegistry
+	}
+
+	contentType := mediaType(r.Header.Get("Content-Type"))
+
+	dec, ok := registry.lookup(contentType)
+	if !ok {
+		r.Close()
+		return fmt.Errorf("grequests: no decoder registered for Content-Type %q", contentType)
+	}
+
+	defer r.Close()
+
+	return dec(r.getInternalReader(), v)
+}
+
+// errReader is an io.Reader that always fails with err – used to surface a decompression setup
+// error through the plain io.Reader that getInternalReader's callers expect.
+type errReader struct{ err error }
+
+func (e *errReader) Read(p []byte) (int, error) {
+	return 0, e.err
+}
+
+// decodingReader wraps the raw response body with a transparent decompressor selected from the
+// Content-Encoding header (gzip, deflate, br), so that .Bytes(), .String(), .Json(), .Xml(), and
+// .Decode() never see compressed bytes. It is idempotent – repeated calls return the same reader.
+func (r *Response) decodingReader() (io.Reader, error) {
+	if r.decodedBody != nil {
+		return r.decodedBody, nil
+	}
+
+	var reader io.Reader = r
+
+	switch strings.ToLower(r.Header.Get("Content-Encoding")) {
+	case "gzip":
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		reader = gz
+	case "deflate":
+		reader = flate.NewReader(reader)
+	case "br":
+		reader = brotli.NewReader(reader)
+	}
+
+	r.decodedBody = reader
+
+	return reader, nil
+}
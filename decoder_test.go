@@ -0,0 +1,119 @@
+package grequests
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"net/http"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("gzip write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("gzip close: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func deflateBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		t.Fatalf("flate writer: %v", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("flate write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("flate close: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func brotliBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := brotli.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("brotli write: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("brotli close: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+func newEncodedResponse(body []byte, encoding string) *Response {
+	resp := newBenchResponse(body)
+	resp.Header = make(http.Header)
+
+	if encoding != "" {
+		resp.Header.Set("Content-Encoding", encoding)
+	}
+
+	return resp
+}
+
+func TestBytesDecompresses(t *testing.T) {
+	payload := []byte(`{"hello":"world"}`)
+
+	cases := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{"gzip", "gzip", gzipBytes(t, payload)},
+		{"deflate", "deflate", deflateBytes(t, payload)},
+		{"br", "br", brotliBytes(t, payload)},
+		{"identity", "", payload},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := newEncodedResponse(tc.body, tc.encoding)
+
+			if got := resp.Bytes(); !bytes.Equal(got, payload) {
+				t.Errorf("Bytes() = %q, want %q", got, payload)
+			}
+		})
+	}
+}
+
+func TestDecodeDispatchesOnContentType(t *testing.T) {
+	resp := newEncodedResponse(gzipBytes(t, []byte(`{"hello":"world"}`)), "gzip")
+	resp.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	var out struct {
+		Hello string `json:"hello"`
+	}
+	if err := resp.Decode(&out); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if out.Hello != "world" {
+		t.Errorf("Hello = %q, want %q", out.Hello, "world")
+	}
+}
+
+func TestDecodeUnknownContentType(t *testing.T) {
+	resp := newEncodedResponse([]byte("not decodable"), "")
+	resp.Header.Set("Content-Type", "application/x-nonsense")
+
+	if err := resp.Decode(&struct{}{}); err == nil {
+		t.Fatal("expected an error for an unregistered Content-Type")
+	}
+}
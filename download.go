@@ -0,0 +1,143 @@
+package grequests
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// defaultChunkSize is used for DownloadToFile/DownloadToWriter when the caller
+// does not supply one via DownloadOptions.
+const defaultChunkSize = 32 * 1024
+
+// ticksPerSec is how often the rate limiter refills its token bucket.
+const ticksPerSec = 10
+
+// DownloadOptions controls optional rate limiting and progress reporting for
+// Response.DownloadToFile and Response.DownloadToWriter.
+type DownloadOptions struct {
+	// MaxBytesPerSec caps the download rate to approximately this many bytes
+	// per second. A value <= 0 disables rate limiting.
+	MaxBytesPerSec int64
+
+	// ProgressFunc, when set, is invoked after every chunk is written with the
+	// number of bytes read so far and RawResponse.ContentLength (-1 if the
+	// server didn't send one).
+	ProgressFunc func(bytesRead, totalBytes int64)
+
+	// ChunkSize is the buffer size used for each read/write pass. Defaults to
+	// 32KB when <= 0.
+	ChunkSize int
+}
+
+// rateLimitedReader wraps an io.Reader with a simple leaky-bucket limiter –
+// it blocks reads until enough tokens have been refilled by the ticker.
+type rateLimitedReader struct {
+	r         io.Reader
+	tokens    int64
+	maxTokens int64
+	ticker    *time.Ticker
+}
+
+// newRateLimitedReader returns a reader that limits r to approximately
+// maxBytesPerSec bytes per second.
+func newRateLimitedReader(r io.Reader, maxBytesPerSec int64) *rateLimitedReader {
+	refill := maxBytesPerSec / ticksPerSec
+	if refill <= 0 {
+		refill = 1
+	}
+
+	return &rateLimitedReader{
+		r:         r,
+		tokens:    refill,
+		maxTokens: refill,
+		ticker:    time.NewTicker(time.Second / ticksPerSec),
+	}
+}
+
+func (rl *rateLimitedReader) Read(p []byte) (int, error) {
+	for rl.tokens <= 0 {
+		<-rl.ticker.C
+		rl.tokens += rl.maxTokens
+	}
+
+	if int64(len(p)) > rl.tokens {
+		p = p[:rl.tokens]
+	}
+
+	n, err := rl.r.Read(p)
+	rl.tokens -= int64(n)
+
+	return n, err
+}
+
+func (rl *rateLimitedReader) Close() error {
+	rl.ticker.Stop()
+	return nil
+}
+
+// DownloadToWriter streams the response body to w, optionally rate limiting
+// the transfer and reporting progress as described by opts. opts may be nil.
+func (r *Response) DownloadToWriter(w io.Writer, opts *DownloadOptions) error {
+	defer r.Close() // This is a noop if we use the internal ByteBuffer
+
+	chunkSize := defaultChunkSize
+	var reader io.Reader = r.getInternalReader()
+
+	if opts != nil {
+		if opts.ChunkSize > 0 {
+			chunkSize = opts.ChunkSize
+		}
+
+		if opts.MaxBytesPerSec > 0 {
+			rl := newRateLimitedReader(reader, opts.MaxBytesPerSec)
+			defer rl.Close()
+			reader = rl
+		}
+	}
+
+	buf := make([]byte, chunkSize)
+	var total int64
+
+	for {
+		n, readErr := reader.Read(buf)
+
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return err
+			}
+
+			total += int64(n)
+
+			if opts != nil && opts.ProgressFunc != nil {
+				opts.ProgressFunc(total, r.RawResponse.ContentLength)
+			}
+		}
+
+		if readErr != nil {
+			if readErr == io.EOF {
+				return nil
+			}
+			return readErr
+		}
+	}
+}
+
+// DownloadToFile allows you to download the contents of the response to a file. An optional
+// DownloadOptions may be passed to rate limit the transfer and/or receive progress updates.
+func (r *Response) DownloadToFile(fileName string, opts ...*DownloadOptions) error {
+	fd, err := os.Create(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	var o *DownloadOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+
+	return r.DownloadToWriter(fd, o)
+}
@@ -0,0 +1,76 @@
+package grequests
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDownloadToWriterProgress(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 100)
+	resp := newBenchResponse(payload)
+
+	var calls []int64
+	var buf bytes.Buffer
+
+	opts := &DownloadOptions{
+		ChunkSize:    10,
+		ProgressFunc: func(read, total int64) { calls = append(calls, read) },
+	}
+
+	if err := resp.DownloadToWriter(&buf, opts); err != nil {
+		t.Fatalf("DownloadToWriter: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), payload) {
+		t.Fatal("downloaded content mismatch")
+	}
+	if len(calls) != 10 {
+		t.Fatalf("ProgressFunc called %d times, want 10", len(calls))
+	}
+	if calls[len(calls)-1] != int64(len(payload)) {
+		t.Errorf("final progress = %d, want %d", calls[len(calls)-1], len(payload))
+	}
+}
+
+func TestDownloadToWriterRateLimited(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 30)
+	resp := newBenchResponse(payload)
+
+	// refill = MaxBytesPerSec/ticksPerSec = 1 token per 100ms tick.
+	opts := &DownloadOptions{MaxBytesPerSec: ticksPerSec}
+
+	start := time.Now()
+	var buf bytes.Buffer
+	if err := resp.DownloadToWriter(&buf, opts); err != nil {
+		t.Fatalf("DownloadToWriter: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if !bytes.Equal(buf.Bytes(), payload) {
+		t.Fatal("downloaded content mismatch")
+	}
+	if elapsed < 200*time.Millisecond {
+		t.Errorf("elapsed = %v, want a rate-limited download of 30 bytes at 1B/tick to take longer", elapsed)
+	}
+}
+
+func TestDownloadToFile(t *testing.T) {
+	payload := []byte("hello world")
+	resp := newBenchResponse(payload)
+
+	path := t.TempDir() + "/out.txt"
+
+	if err := resp.DownloadToFile(path); err != nil {
+		t.Fatalf("DownloadToFile: %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("file content = %q, want %q", got, payload)
+	}
+}
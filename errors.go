@@ -0,0 +1,46 @@
+package grequests
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// HTTPError describes a non-2xx response. It's returned by ErrorForStatus, and is automatically
+// set as Response.Error when the request sets RequestOptions.ErrorOnNon2xx.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	URL        *url.URL
+	Method     string
+	Body       []byte
+	Header     http.Header
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("grequests: %s %s: %s", e.Method, e.URL, e.Status)
+}
+
+// ErrorForStatus returns an *HTTPError describing the response when !r.Ok, or nil otherwise. It
+// buffers the body via .Bytes() as a side effect, so the body remains available to .Json()/
+// .String() afterwards even though the error has already been returned. Responses built from a
+// connection-level failure (RawResponse == nil) have no status to describe, so r.Error is
+// returned unchanged instead.
+func (r *Response) ErrorForStatus() error {
+	if r.RawResponse == nil {
+		return r.Error
+	}
+
+	if r.Ok {
+		return nil
+	}
+
+	return &HTTPError{
+		StatusCode: r.StatusCode,
+		Status:     r.RawResponse.Status,
+		URL:        r.RawResponse.Request.URL,
+		Method:     r.RawResponse.Request.Method,
+		Header:     r.Header,
+		Body:       r.Bytes(),
+	}
+}
@@ -0,0 +1,66 @@
+package grequests
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func doBuildResponse(t *testing.T, status int, errorOnNon2xx bool) *Response {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte("body"))
+	}))
+	defer server.Close()
+
+	httpResp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+
+	return buildResponse(httpResp, nil, nil, 0, nil, errorOnNon2xx)
+}
+
+func TestErrorOnNon2xxLeavesSuccessResponsesAlone(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusCreated, http.StatusNoContent, http.StatusPartialContent} {
+		resp := doBuildResponse(t, status, true)
+
+		if !resp.Ok {
+			t.Errorf("status %d: Ok = false, want true", status)
+		}
+		if resp.Error != nil {
+			t.Errorf("status %d: Error = %v, want nil", status, resp.Error)
+		}
+	}
+}
+
+func TestErrorOnNon2xxPopulatesHTTPError(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusInternalServerError} {
+		resp := doBuildResponse(t, status, true)
+
+		var httpErr *HTTPError
+		if !errors.As(resp.Error, &httpErr) {
+			t.Fatalf("status %d: Error = %v, want *HTTPError", status, resp.Error)
+		}
+
+		if httpErr.StatusCode != status {
+			t.Errorf("StatusCode = %d, want %d", httpErr.StatusCode, status)
+		}
+
+		if got := resp.String(); got != "body" {
+			t.Errorf("body still readable after error = %q, want %q", got, "body")
+		}
+	}
+}
+
+func TestErrorForStatusNilRawResponse(t *testing.T) {
+	wantErr := errors.New("connection refused")
+	resp := buildResponse(nil, wantErr, nil, 0, nil, true)
+
+	if got := resp.ErrorForStatus(); got != wantErr {
+		t.Errorf("ErrorForStatus() = %v, want %v", got, wantErr)
+	}
+}
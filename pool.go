@@ -0,0 +1,36 @@
+package grequests
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+)
+
+// bufferPool is the package-default pool of *bytes.Buffer used to stage response bodies before
+// they're handed to the caller as .Bytes()/.String(). Pooling avoids every response independently
+// allocating and growing a buffer, which matters at scale for high-throughput callers. It's an
+// atomic.Pointer rather than a plain var since SetBufferPool may race with concurrent
+// getPooledBuffer/putPooledBuffer calls from in-flight requests.
+var bufferPool atomic.Pointer[sync.Pool]
+
+func init() {
+	bufferPool.Store(&sync.Pool{
+		New: func() interface{} { return &bytes.Buffer{} },
+	})
+}
+
+// SetBufferPool replaces the package-default buffer pool. High-throughput callers – e.g. scanners
+// issuing thousands of requests/sec – can use this to inject a pool tuned to their workload. Safe
+// to call concurrently with in-flight requests.
+func SetBufferPool(pool *sync.Pool) {
+	bufferPool.Store(pool)
+}
+
+func getPooledBuffer() *bytes.Buffer {
+	return bufferPool.Load().Get().(*bytes.Buffer)
+}
+
+func putPooledBuffer(buf *bytes.Buffer) {
+	buf.Reset()
+	bufferPool.Load().Put(buf)
+}
@@ -0,0 +1,20 @@
+package grequests
+
+// RequestOptions is where any options for the request should be organized. They tune how the
+// Response built from a request behaves, as opposed to the request itself.
+type RequestOptions struct {
+	// MaxInMemoryBytes caps how much of a response body is buffered in memory by .String(),
+	// .Bytes(), .Json(), and .Xml(). Once the body exceeds this many bytes, the already-buffered
+	// prefix plus the remainder of the body is spilled to a temp file instead of growing the
+	// in-memory buffer without bound. Defaults to defaultMaxInMemoryBytes when <= 0.
+	MaxInMemoryBytes int64
+
+	// Decoders overrides the registry Response.Decode consults for this request. When nil,
+	// Decode falls back to defaultDecoderRegistry.
+	Decoders *DecoderRegistry
+
+	// ErrorOnNon2xx, when true, automatically populates Response.Error with an *HTTPError (see
+	// ErrorForStatus) for any response that isn't Ok, instead of leaving callers to check
+	// StatusCode/ClientError/ServerError themselves.
+	ErrorOnNon2xx bool
+}
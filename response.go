@@ -1,12 +1,10 @@
 package grequests
 
 import (
-	"bytes"
 	"encoding/json"
 	"encoding/xml"
 	"io"
 	"net/http"
-	"os"
 )
 
 type Response struct {
@@ -24,26 +22,61 @@ type Response struct {
 	// StatusCode is the HTTP Status Code returned by the HTTP Response. Taken from resp.StatusCode
 	StatusCode int
 
+	// ClientError is true when the server returned a 4xx status code
+	ClientError bool
+
+	// ServerError is true when the server returned a 5xx status code
+	ServerError bool
+
 	// Header is a net/http/Header structure
 	Header http.Header
 
-	internalByteBuffer *bytes.Buffer
+	internalByteBuffer *internalBuffer
+
+	// maxInMemoryBytes is RequestOptions.MaxInMemoryBytes, carried over so respBytesBuffer knows
+	// when to spill to disk.
+	maxInMemoryBytes int64
+
+	// decoders is RequestOptions.Decoders; nil means Decode falls back to defaultDecoderRegistry.
+	decoders *DecoderRegistry
+
+	// decodedBody memoizes the Content-Encoding-aware decompressing reader built by decodingReader.
+	decodedBody io.Reader
+
+	redirectChain []*ChainItem
 }
 
-func buildResponse(resp *http.Response, err error) *Response {
+// buildResponse assembles a Response from the result of a round trip. redirectChain, if non-nil,
+// is the hop history accumulated by a CheckRedirect hook installed on the client before the
+// request was made (see newChainCheckRedirect). maxInMemoryBytes is RequestOptions.MaxInMemoryBytes
+// and decoders is RequestOptions.Decoders. When errorOnNon2xx is set and the response isn't Ok,
+// Response.Error is populated with an *HTTPError (see ErrorForStatus), with the body pre-buffered
+// so callers can still inspect it via .Json()/.String() after the error is returned.
+func buildResponse(resp *http.Response, err error, redirectChain []*ChainItem, maxInMemoryBytes int64, decoders *DecoderRegistry, errorOnNon2xx bool) *Response {
 	// If the connection didn't succeed we just return a blank response
 	if err != nil {
 		return &Response{Error: err}
 	}
 
-	return &Response{
+	r := &Response{
 		// If your code is within the 2xx range – the response is considered `Ok`
-		Ok:          resp.StatusCode <= 200 && resp.StatusCode < 300,
-		Error:       nil,
-		RawResponse: resp,
-		StatusCode:  resp.StatusCode,
-		Header:      resp.Header,
+		Ok:               resp.StatusCode >= 200 && resp.StatusCode < 300,
+		Error:            nil,
+		RawResponse:      resp,
+		StatusCode:       resp.StatusCode,
+		ClientError:      resp.StatusCode >= 400 && resp.StatusCode < 500,
+		ServerError:      resp.StatusCode >= 500 && resp.StatusCode < 600,
+		Header:           resp.Header,
+		maxInMemoryBytes: maxInMemoryBytes,
+		decoders:         decoders,
+		redirectChain:    redirectChain,
 	}
+
+	if errorOnNon2xx && !r.Ok {
+		r.Error = r.ErrorForStatus()
+	}
+
+	return r
 }
 
 // Read is part of our ability to support io.ReadCloser if someone wants to make use of the raw body
@@ -56,32 +89,25 @@ func (r *Response) Close() error {
 	return r.RawResponse.Body.Close()
 }
 
-// DownloadToFile allows you to download the contents of the response to a file
-func (r *Response) DownloadToFile(fileName string) error {
-	fd, err := os.Create(fileName)
-
-	if err != nil {
-		return err
+// getInternalReader because we implement io.ReadCloser and optionally hold a large buffer of the response (created by
+// the user's request). The raw body, when read directly, is transparently decompressed according
+// to the response's Content-Encoding.
+func (r *Response) getInternalReader() io.Reader {
+	if r.internalByteBuffer != nil {
+		return r.internalByteBuffer.Reader()
 	}
 
-	defer r.Close() // This is a noop if we use the internal ByteBuffer
-	defer fd.Close()
-
-	if _, err := io.Copy(fd, r.getInternalReader()); err != nil && err != io.EOF {
-		return err
+	reader, err := r.decodingReader()
+	if err != nil {
+		return &errReader{err: err}
 	}
 
-	return nil
+	return reader
 }
 
-// getInternalReader because we implement io.ReadCloser and optionally hold a large buffer of the response (created by
-// the user's request)
-func (r *Response) getInternalReader() io.Reader {
-	if r.internalByteBuffer != nil {
-		return r.internalByteBuffer
-	}
-	return r
-}
+// XMLCharDecoder matches xml.Decoder.CharsetReader, letting callers of Xml decode documents whose
+// encoding isn't UTF-8 or US-ASCII.
+type XMLCharDecoder func(charset string, input io.Reader) (io.Reader, error)
 
 // Xml is a method that will populate a struct that is provided `userStruct` with the XML returned within the
 // response body
@@ -115,7 +141,8 @@ func (r *Response) Json(userStruct interface{}) error {
 }
 
 // respBytesBuffer is a utility method that will populate the internal byte reader – this is largely used for .String()
-// and .Bytes()
+// and .Bytes(). Once the body grows past maxInMemoryBytes it is spilled to a temp file instead of
+// growing the in-memory buffer without bound.
 func (r *Response) respBytesBuffer() error {
 
 	if r.internalByteBuffer != nil {
@@ -124,13 +151,18 @@ func (r *Response) respBytesBuffer() error {
 
 	defer r.Close()
 
-	r.internalByteBuffer = &bytes.Buffer{}
-	r.internalByteBuffer.Grow(int(r.RawResponse.ContentLength))
+	reader, err := r.decodingReader()
+	if err != nil {
+		return err
+	}
 
-	if _, err := io.Copy(r.internalByteBuffer, r); err != nil && err != io.EOF {
+	buf, err := fillInternalBuffer(reader, r.RawResponse.ContentLength, r.maxInMemoryBytes)
+	if err != nil {
 		return err
 	}
 
+	r.internalByteBuffer = buf
+
 	return nil
 
 }
@@ -140,7 +172,12 @@ func (r *Response) Bytes() []byte {
 		return nil
 	}
 
-	return r.internalByteBuffer.Bytes()
+	b, err := r.internalByteBuffer.Bytes()
+	if err != nil {
+		return nil
+	}
+
+	return b
 
 }
 
@@ -149,12 +186,39 @@ func (r *Response) String() string {
 		return ""
 	}
 
-	return r.internalByteBuffer.String()
+	b, err := r.internalByteBuffer.Bytes()
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
+
+// Size returns the number of response body bytes buffered so far by .String() or .Bytes() (or by
+// ErrorForStatus). It returns 0 if the body hasn't been buffered yet; .Json() and .Xml() stream the
+// body without buffering it, so calling only those leaves Size at 0.
+func (r *Response) Size() int64 {
+	if r.internalByteBuffer == nil {
+		return 0
+	}
+
+	return r.internalByteBuffer.size
 }
 
 // ClearInternalBuffer is a function that will clear the internal buffer that we use to hold the .String() and .Bytes()
-// data. Once you have used these functions – you may want to free up the memory.
+// data. Once you have used these functions – you may want to free up the memory. If the buffer was spilled to a temp
+// file, this also removes that file.
 func (r *Response) ClearInternalBuffer() {
-	r.internalByteBuffer.Reset()
+	if r.internalByteBuffer == nil {
+		return
+	}
+
+	r.internalByteBuffer.Close()
 	r.internalByteBuffer = nil
-}
\ No newline at end of file
+}
+
+// Release is the buffer-pooling equivalent of ClearInternalBuffer – it returns the internal buffer
+// to the active buffer pool (see SetBufferPool) and removes any spill file.
+func (r *Response) Release() {
+	r.ClearInternalBuffer()
+}
@@ -0,0 +1,43 @@
+package grequests
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestRespBytesBufferSpillsToDiskPastCap(t *testing.T) {
+	payload := append([]byte(`{"hello":"world"}`), bytes.Repeat([]byte(" "), 1024)...)
+
+	resp := newBenchResponse(payload)
+	resp.maxInMemoryBytes = 8 // force a spill well before the body ends
+
+	if got := resp.Bytes(); !bytes.Equal(got, payload) {
+		t.Fatalf("Bytes() = %q, want %q", got, payload)
+	}
+
+	if resp.internalByteBuffer.file == nil {
+		t.Fatal("expected body to have spilled to a temp file")
+	}
+	if resp.Size() != int64(len(payload)) {
+		t.Errorf("Size() = %d, want %d", resp.Size(), len(payload))
+	}
+
+	// Decoding still works against the spilled file.
+	var out struct {
+		Hello string `json:"hello"`
+	}
+	if err := resp.Json(&out); err != nil {
+		t.Fatalf("Json: %v", err)
+	}
+	if out.Hello != "world" {
+		t.Errorf("Hello = %q, want %q", out.Hello, "world")
+	}
+
+	name := resp.internalByteBuffer.file.Name()
+	resp.ClearInternalBuffer()
+
+	if _, err := os.Stat(name); !os.IsNotExist(err) {
+		t.Errorf("spill file %q still exists after ClearInternalBuffer", name)
+	}
+}